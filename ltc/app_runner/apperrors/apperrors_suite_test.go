@@ -0,0 +1,13 @@
+package apperrors_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestApperrors(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "App Errors Suite")
+}
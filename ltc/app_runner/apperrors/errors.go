@@ -0,0 +1,139 @@
+// Package apperrors defines exported error types for the app_runner
+// packages, so callers can branch on failure class (conflict, not found,
+// invalid) instead of matching on error strings.
+package apperrors
+
+import (
+	"fmt"
+
+	"github.com/cloudfoundry-incubator/receptor"
+)
+
+// ConflictError is implemented by errors describing an operation that
+// failed because the target resource already exists.
+type ConflictError interface {
+	error
+	IsConflict() bool
+}
+
+// NotFoundError is implemented by errors describing an operation that
+// failed because the target resource does not exist.
+type NotFoundError interface {
+	error
+	IsNotFound() bool
+}
+
+// InvalidError is implemented by errors describing a request that was
+// rejected as invalid, either by the app runner itself or by the receptor.
+type InvalidError interface {
+	error
+	IsInvalid() bool
+}
+
+// ReservedAppNameError is returned when a caller attempts to create or
+// submit an app under a name reserved for lattice-internal components.
+type ReservedAppNameError struct {
+	Name string
+}
+
+func (e ReservedAppNameError) Error() string {
+	return fmt.Sprintf("%s is a reserved app name. It is used internally to stream debug logs for lattice components.", e.Name)
+}
+
+func (e ReservedAppNameError) IsInvalid() bool { return true }
+
+// AppExistsError is returned when a caller attempts to create or submit an
+// app whose name is already desired on Diego.
+type AppExistsError struct {
+	Name string
+}
+
+func (e AppExistsError) Error() string {
+	return fmt.Sprintf("%s is already running", e.Name)
+}
+
+func (e AppExistsError) IsConflict() bool { return true }
+
+// AppNotStartedError is returned when a caller attempts to scale, update
+// the routes of, or remove an app that has no desired LRP.
+type AppNotStartedError struct {
+	Name string
+}
+
+func (e AppNotStartedError) Error() string {
+	return fmt.Sprintf("%s is not started", e.Name)
+}
+
+func (e AppNotStartedError) IsNotFound() bool { return true }
+
+// SSHNotEnabledError is returned when SSH details are requested for an app
+// that was not created with EnableSSH set.
+type SSHNotEnabledError struct {
+	Name string
+}
+
+func (e SSHNotEnabledError) Error() string {
+	return fmt.Sprintf("%s was not started with SSH enabled", e.Name)
+}
+
+func (e SSHNotEnabledError) IsInvalid() bool { return true }
+
+// InvalidInstanceIndexError is returned when an SSH request references an
+// instance index outside the range of the app's running instances.
+type InvalidInstanceIndexError struct {
+	InstanceIndex int
+	Instances     int
+}
+
+func (e InvalidInstanceIndexError) Error() string {
+	return fmt.Sprintf("instance index %d is out of range (app has %d instances)", e.InstanceIndex, e.Instances)
+}
+
+func (e InvalidInstanceIndexError) IsInvalid() bool { return true }
+
+// ReceptorError wraps a failure returned by the receptor client, preserving
+// its HTTP status class (conflict/not found/invalid) so callers can
+// unwrap Cause and branch on it without string matching.
+type ReceptorError struct {
+	Cause error
+}
+
+func (e ReceptorError) Error() string { return e.Cause.Error() }
+
+func (e ReceptorError) IsConflict() bool {
+	switch e.errorType() {
+	case receptor.DesiredLRPAlreadyExists, receptor.TaskGuidAlreadyExists:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e ReceptorError) IsNotFound() bool {
+	switch e.errorType() {
+	case receptor.DesiredLRPNotFound, receptor.TaskNotFound:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e ReceptorError) IsInvalid() bool { return e.errorType() == receptor.InvalidRequest }
+
+func (e ReceptorError) errorType() receptor.ErrorType {
+	receptorErr, ok := e.Cause.(receptor.Error)
+	if !ok {
+		return ""
+	}
+	return receptorErr.Type
+}
+
+// WrapReceptorError wraps a non-nil error returned by the receptor client in
+// a ReceptorError, so its status class survives as far as the caller of
+// AppRunner. A nil err is passed through unchanged.
+func WrapReceptorError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return ReceptorError{Cause: err}
+}
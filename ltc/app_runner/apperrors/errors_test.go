@@ -0,0 +1,76 @@
+package apperrors_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/lattice/ltc/app_runner/apperrors"
+	"github.com/cloudfoundry-incubator/receptor"
+)
+
+var _ = Describe("apperrors", func() {
+	Describe("ReservedAppNameError", func() {
+		It("is invalid", func() {
+			err := apperrors.ReservedAppNameError{Name: "lattice-debug"}
+			Expect(err.IsInvalid()).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("lattice-debug"))
+		})
+	})
+
+	Describe("AppExistsError", func() {
+		It("is a conflict", func() {
+			err := apperrors.AppExistsError{Name: "my-app"}
+			Expect(err.IsConflict()).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("my-app"))
+		})
+	})
+
+	Describe("AppNotStartedError", func() {
+		It("is not found", func() {
+			err := apperrors.AppNotStartedError{Name: "my-app"}
+			Expect(err.IsNotFound()).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("my-app"))
+		})
+	})
+
+	Describe("SSHNotEnabledError", func() {
+		It("is invalid", func() {
+			err := apperrors.SSHNotEnabledError{Name: "my-app"}
+			Expect(err.IsInvalid()).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("my-app"))
+		})
+	})
+
+	Describe("InvalidInstanceIndexError", func() {
+		It("is invalid", func() {
+			err := apperrors.InvalidInstanceIndexError{InstanceIndex: 3, Instances: 2}
+			Expect(err.IsInvalid()).To(BeTrue())
+			Expect(err.Error()).To(ContainSubstring("3"))
+		})
+	})
+
+	Describe("ReceptorError", func() {
+		It("classifies a conflict from the receptor", func() {
+			err := apperrors.WrapReceptorError(receptor.Error{Type: receptor.DesiredLRPAlreadyExists, Message: "nope"})
+			receptorErr := err.(apperrors.ReceptorError)
+			Expect(receptorErr.IsConflict()).To(BeTrue())
+			Expect(receptorErr.IsNotFound()).To(BeFalse())
+		})
+
+		It("classifies a task conflict from the receptor", func() {
+			err := apperrors.WrapReceptorError(receptor.Error{Type: receptor.TaskGuidAlreadyExists, Message: "nope"})
+			receptorErr := err.(apperrors.ReceptorError)
+			Expect(receptorErr.IsConflict()).To(BeTrue())
+		})
+
+		It("classifies a not found from the receptor", func() {
+			err := apperrors.WrapReceptorError(receptor.Error{Type: receptor.DesiredLRPNotFound, Message: "nope"})
+			receptorErr := err.(apperrors.ReceptorError)
+			Expect(receptorErr.IsNotFound()).To(BeTrue())
+		})
+
+		It("passes nil errors through unchanged", func() {
+			Expect(apperrors.WrapReceptorError(nil)).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,26 @@
+package docker_app_runner
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("commandMonitorAction", func() {
+	It("runs a single-element command as a shell string, preserving pipes and redirection", func() {
+		path, args := commandMonitorAction([]string{"echo -n '' | telnet localhost 3456 >/dev/null 2>&1 && true"})
+		Expect(path).To(Equal("sh"))
+		Expect(args).To(Equal([]string{"-c", "echo -n '' | telnet localhost 3456 >/dev/null 2>&1 && true"}))
+	})
+
+	It("runs a multi-element command directly, without a shell", func() {
+		path, args := commandMonitorAction([]string{"/bin/healthcheck", "-port", "8080"})
+		Expect(path).To(Equal("/bin/healthcheck"))
+		Expect(args).To(Equal([]string{"-port", "8080"}))
+	})
+
+	It("is a no-op when there is no command", func() {
+		path, args := commandMonitorAction(nil)
+		Expect(path).To(Equal("true"))
+		Expect(args).To(BeNil())
+	})
+})
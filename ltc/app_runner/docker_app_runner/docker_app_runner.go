@@ -2,11 +2,13 @@ package docker_app_runner
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"golang.org/x/crypto/ssh"
+
+	"github.com/cloudfoundry-incubator/lattice/ltc/app_runner/apperrors"
 	"github.com/cloudfoundry-incubator/lattice/ltc/app_runner/docker_repository_name_formatter"
 	"github.com/cloudfoundry-incubator/lattice/ltc/logs/reserved_app_ids"
 	"github.com/cloudfoundry-incubator/lattice/ltc/route_helpers"
@@ -20,8 +22,10 @@ const (
 	NoMonitor MonitorMethod = iota
 	PortMonitor
 	URLMonitor
+	CommandMonitor
 
-	AttemptedToCreateLatticeDebugErrorMessage = reserved_app_ids.LatticeDebugLogStreamAppId + " is a reserved app name. It is used internally to stream debug logs for lattice components."
+	sshProxyPort     uint16 = 2222
+	sshRouteLogGuid         = "SSH"
 )
 
 //go:generate counterfeiter -o fake_app_runner/fake_app_runner.go . AppRunner
@@ -31,6 +35,18 @@ type AppRunner interface {
 	ScaleApp(name string, instances int) error
 	UpdateAppRoutes(name string, routes RouteOverrides) error
 	RemoveApp(name string) error
+
+	SSHDetails(name string, instanceIndex int) (SSHInfo, error)
+	RemoveSSH(name string) error
+	RotateSSHKey(name string, instanceIndex int) (SSHInfo, error)
+}
+
+// SSHInfo describes how to reach an app instance over Diego SSH, as returned
+// by AppRunner.SSHDetails and AppRunner.RotateSSHKey.
+type SSHInfo struct {
+	PublicKey   string
+	HostAddress string
+	User        string
 }
 
 type MonitorConfig struct {
@@ -38,6 +54,10 @@ type MonitorConfig struct {
 	URI     string
 	Port    uint16
 	Timeout time.Duration
+	Command []string
+	User    string
+	Dir     string
+	Env     map[string]string
 }
 
 type RouteOverrides []RouteOverride
@@ -64,34 +84,37 @@ type CreateDockerAppParams struct {
 	RouteOverrides       RouteOverrides
 	NoRoutes             bool
 	Timeout              time.Duration
+	EnableSSH            bool
 }
 
 const (
 	healthcheckDownloadUrl string = "http://file_server.service.dc1.consul:8080/v1/static/healthcheck.tgz"
+	sshdDownloadUrl        string = "http://file_server.service.dc1.consul:8080/v1/static/diego-sshd.tgz"
 	lrpDomain              string = "lattice"
 )
 
 type appRunner struct {
 	receptorClient receptor.Client
 	systemDomain   string
+	keyGenerator   KeyGenerator
 }
 
-func New(receptorClient receptor.Client, systemDomain string) AppRunner {
-	return &appRunner{receptorClient, systemDomain}
+func New(receptorClient receptor.Client, systemDomain string, keyGenerator KeyGenerator) AppRunner {
+	return &appRunner{receptorClient, systemDomain, keyGenerator}
 }
 
 func (appRunner *appRunner) CreateDockerApp(params CreateDockerAppParams) error {
 	if params.Name == reserved_app_ids.LatticeDebugLogStreamAppId {
-		return errors.New(AttemptedToCreateLatticeDebugErrorMessage)
+		return apperrors.ReservedAppNameError{Name: params.Name}
 	}
 	if exists, err := appRunner.desiredLRPExists(params.Name); err != nil {
 		return err
 	} else if exists {
-		return newExistingAppError(params.Name)
+		return apperrors.AppExistsError{Name: params.Name}
 	}
 
 	if err := appRunner.receptorClient.UpsertDomain(lrpDomain, 0); err != nil {
-		return err
+		return apperrors.WrapReceptorError(err)
 	}
 
 	return appRunner.desireLrp(params)
@@ -107,28 +130,28 @@ func (appRunner *appRunner) SubmitLrp(submitLrpJson []byte) (string, error) {
 	}
 
 	if desiredLRP.ProcessGuid == reserved_app_ids.LatticeDebugLogStreamAppId {
-		return desiredLRP.ProcessGuid, errors.New(AttemptedToCreateLatticeDebugErrorMessage)
+		return desiredLRP.ProcessGuid, apperrors.ReservedAppNameError{Name: desiredLRP.ProcessGuid}
 	}
 
 	if exists, err := appRunner.desiredLRPExists(desiredLRP.ProcessGuid); err != nil {
 		return desiredLRP.ProcessGuid, err
 	} else if exists {
-		return desiredLRP.ProcessGuid, newExistingAppError(desiredLRP.ProcessGuid)
+		return desiredLRP.ProcessGuid, apperrors.AppExistsError{Name: desiredLRP.ProcessGuid}
 	}
 
 	if err := appRunner.receptorClient.UpsertDomain(lrpDomain, 0); err != nil {
-		return desiredLRP.ProcessGuid, err
+		return desiredLRP.ProcessGuid, apperrors.WrapReceptorError(err)
 	}
 
 	err = appRunner.receptorClient.CreateDesiredLRP(desiredLRP)
-	return desiredLRP.ProcessGuid, err
+	return desiredLRP.ProcessGuid, apperrors.WrapReceptorError(err)
 }
 
 func (appRunner *appRunner) ScaleApp(name string, instances int) error {
 	if exists, err := appRunner.desiredLRPExists(name); err != nil {
 		return err
 	} else if !exists {
-		return newAppNotStartedError(name)
+		return apperrors.AppNotStartedError{Name: name}
 	}
 
 	return appRunner.updateLrpInstances(name, instances)
@@ -138,7 +161,7 @@ func (appRunner *appRunner) UpdateAppRoutes(name string, routes RouteOverrides)
 	if exists, err := appRunner.desiredLRPExists(name); err != nil {
 		return err
 	} else if !exists {
-		return newAppNotStartedError(name)
+		return apperrors.AppNotStartedError{Name: name}
 	}
 
 	return appRunner.updateLrpRoutes(name, routes)
@@ -148,16 +171,251 @@ func (appRunner *appRunner) RemoveApp(name string) error {
 	if lrpExists, err := appRunner.desiredLRPExists(name); err != nil {
 		return err
 	} else if !lrpExists {
-		return newAppNotStartedError(name)
+		return apperrors.AppNotStartedError{Name: name}
+	}
+
+	return apperrors.WrapReceptorError(appRunner.receptorClient.DeleteDesiredLRP(name))
+}
+
+func (appRunner *appRunner) SSHDetails(name string, instanceIndex int) (SSHInfo, error) {
+	desiredLRP, err := appRunner.desiredLRPByProcessGuid(name)
+	if err != nil {
+		return SSHInfo{}, err
+	}
+
+	if err := validateInstanceIndex(instanceIndex, desiredLRP.Instances); err != nil {
+		return SSHInfo{}, err
+	}
+
+	sshRoute, err := sshRouteFromRoutes(name, desiredLRP.Routes)
+	if err != nil {
+		return SSHInfo{}, err
+	}
+
+	publicKey, err := publicKeyFromPEM(sshRoute.PrivateKey)
+	if err != nil {
+		return SSHInfo{}, err
 	}
 
-	return appRunner.receptorClient.DeleteDesiredLRP(name)
+	return SSHInfo{
+		PublicKey:   string(ssh.MarshalAuthorizedKey(publicKey)),
+		HostAddress: fmt.Sprintf("%s:%d", appRunner.systemDomain, sshProxyPort),
+		User:        fmt.Sprintf("diego:%s/%d", name, instanceIndex),
+	}, nil
+}
+
+func validateInstanceIndex(instanceIndex, instances int) error {
+	if instanceIndex < 0 || instanceIndex >= instances {
+		return apperrors.InvalidInstanceIndexError{InstanceIndex: instanceIndex, Instances: instances}
+	}
+	return nil
+}
+
+func (appRunner *appRunner) RemoveSSH(name string) error {
+	if exists, err := appRunner.desiredLRPExists(name); err != nil {
+		return err
+	} else if !exists {
+		return apperrors.AppNotStartedError{Name: name}
+	}
+
+	routingInfo, err := appRunner.routesWithoutSSH(name)
+	if err != nil {
+		return err
+	}
+
+	return apperrors.WrapReceptorError(appRunner.receptorClient.UpdateDesiredLRP(
+		name,
+		receptor.DesiredLRPUpdateRequest{
+			Routes: routingInfo,
+		},
+	))
+}
+
+// RotateSSHKey generates a fresh client/host keypair for the app's SSH
+// access and actually revokes the old one: since the receptor API has no
+// way to update a running DesiredLRP's Action, it deletes and recreates
+// the LRP with the new authorizedKey baked into the diego-sshd RunAction,
+// rather than just swapping the route's PrivateKey out from under an
+// Action that would keep trusting the old public key.
+func (appRunner *appRunner) RotateSSHKey(name string, instanceIndex int) (SSHInfo, error) {
+	desiredLRP, err := appRunner.desiredLRPByProcessGuid(name)
+	if err != nil {
+		return SSHInfo{}, err
+	}
+
+	if err := validateInstanceIndex(instanceIndex, desiredLRP.Instances); err != nil {
+		return SSHInfo{}, err
+	}
+
+	privateKey, publicKey, err := appRunner.keyGenerator.GenerateRSAKeyPair()
+	if err != nil {
+		return SSHInfo{}, err
+	}
+
+	hostKey, _, err := appRunner.keyGenerator.GenerateRSAKeyPair()
+	if err != nil {
+		return SSHInfo{}, err
+	}
+
+	authorizedKey := string(ssh.MarshalAuthorizedKey(publicKey))
+
+	action, err := replaceSSHDAction(name, desiredLRP.Action, hostKey, authorizedKey)
+	if err != nil {
+		return SSHInfo{}, err
+	}
+
+	sshRouteJson, err := json.Marshal(route_helpers.DiegoSSHRoute{
+		Port:       sshProxyPort,
+		PrivateKey: string(privateKey),
+	})
+	if err != nil {
+		return SSHInfo{}, err
+	}
+
+	routingInfo := receptor.RoutingInfo{}
+	for routeName, route := range desiredLRP.Routes {
+		routingInfo[routeName] = route
+	}
+	rawMessage := json.RawMessage(sshRouteJson)
+	routingInfo[route_helpers.DiegoSSHRouteName] = &rawMessage
+
+	originalReq := desiredLRPCreateRequestFromResponse(desiredLRP, desiredLRP.Routes, desiredLRP.Action)
+	rotatedReq := desiredLRPCreateRequestFromResponse(desiredLRP, routingInfo, action)
+
+	if err := appRunner.receptorClient.DeleteDesiredLRP(name); err != nil {
+		return SSHInfo{}, apperrors.WrapReceptorError(err)
+	}
+
+	if err := appRunner.receptorClient.CreateDesiredLRP(rotatedReq); err != nil {
+		// The create with the new key failed after the old LRP was already
+		// deleted; best-effort restore the original so the app doesn't stay
+		// down just because the rotation itself couldn't complete.
+		appRunner.receptorClient.CreateDesiredLRP(originalReq)
+		return SSHInfo{}, apperrors.WrapReceptorError(err)
+	}
+
+	return SSHInfo{
+		PublicKey:   authorizedKey,
+		HostAddress: fmt.Sprintf("%s:%d", appRunner.systemDomain, sshProxyPort),
+		User:        fmt.Sprintf("diego:%s/%d", name, instanceIndex),
+	}, nil
+}
+
+// desiredLRPCreateRequestFromResponse rebuilds the create request for an
+// existing LRP from its current state, so RotateSSHKey can recreate it with
+// routes/action overridden and, if that recreate fails, restore it exactly
+// as it was rather than leaving the app deleted.
+func desiredLRPCreateRequestFromResponse(desiredLRP receptor.DesiredLRPResponse, routes receptor.RoutingInfo, action models.Action) receptor.DesiredLRPCreateRequest {
+	return receptor.DesiredLRPCreateRequest{
+		ProcessGuid:          desiredLRP.ProcessGuid,
+		Domain:               desiredLRP.Domain,
+		RootFS:               desiredLRP.RootFS,
+		Instances:            desiredLRP.Instances,
+		Routes:               routes,
+		CPUWeight:            desiredLRP.CPUWeight,
+		MemoryMB:             desiredLRP.MemoryMB,
+		DiskMB:               desiredLRP.DiskMB,
+		Privileged:           desiredLRP.Privileged,
+		Ports:                desiredLRP.Ports,
+		LogGuid:              desiredLRP.LogGuid,
+		LogSource:            desiredLRP.LogSource,
+		MetricsGuid:          desiredLRP.MetricsGuid,
+		EnvironmentVariables: desiredLRP.EnvironmentVariables,
+		Setup:                desiredLRP.Setup,
+		Action:               action,
+		Monitor:              desiredLRP.Monitor,
+	}
+}
+
+func sshdRunAction(hostKey []byte, authorizedKey string) *models.RunAction {
+	return &models.RunAction{
+		Path: "/tmp/diego-sshd",
+		Args: []string{
+			"-address=0.0.0.0:" + fmt.Sprint(sshProxyPort),
+			"-hostKey=" + string(hostKey),
+			"-authorizedKey=" + authorizedKey,
+		},
+		LogSource: sshRouteLogGuid,
+	}
+}
+
+// replaceSSHDAction swaps the diego-sshd RunAction's -hostKey/-authorizedKey
+// arguments for newHostKey/newAuthorizedKey inside the ParallelAction that
+// desireLrp builds when EnableSSH is set, leaving every other action
+// untouched. It returns SSHNotEnabledError if action isn't the shape
+// desireLrp produces for an SSH-enabled app.
+func replaceSSHDAction(name string, action models.Action, newHostKey []byte, newAuthorizedKey string) (models.Action, error) {
+	parallelAction, ok := action.(*models.ParallelAction)
+	if !ok {
+		return nil, apperrors.SSHNotEnabledError{Name: name}
+	}
+
+	actions := make([]models.Action, len(parallelAction.Actions))
+	copy(actions, parallelAction.Actions)
+
+	for i, a := range actions {
+		if runAction, ok := a.(*models.RunAction); ok && runAction.Path == "/tmp/diego-sshd" {
+			actions[i] = sshdRunAction(newHostKey, newAuthorizedKey)
+			return &models.ParallelAction{Actions: actions}, nil
+		}
+	}
+
+	return nil, apperrors.SSHNotEnabledError{Name: name}
+}
+
+func (appRunner *appRunner) routesWithoutSSH(name string) (receptor.RoutingInfo, error) {
+	desiredLRP, err := appRunner.desiredLRPByProcessGuid(name)
+	if err != nil {
+		return nil, err
+	}
+
+	routingInfo := desiredLRP.Routes
+	delete(routingInfo, route_helpers.DiegoSSHRouteName)
+	return routingInfo, nil
+}
+
+func (appRunner *appRunner) desiredLRPByProcessGuid(name string) (receptor.DesiredLRPResponse, error) {
+	desiredLRPs, err := appRunner.receptorClient.DesiredLRPs()
+	if err != nil {
+		return receptor.DesiredLRPResponse{}, apperrors.WrapReceptorError(err)
+	}
+
+	for _, desiredLRP := range desiredLRPs {
+		if desiredLRP.ProcessGuid == name {
+			return desiredLRP, nil
+		}
+	}
+
+	return receptor.DesiredLRPResponse{}, apperrors.AppNotStartedError{Name: name}
+}
+
+func sshRouteFromRoutes(name string, routes receptor.RoutingInfo) (route_helpers.DiegoSSHRoute, error) {
+	rawMessage, ok := routes[route_helpers.DiegoSSHRouteName]
+	if !ok || rawMessage == nil {
+		return route_helpers.DiegoSSHRoute{}, apperrors.SSHNotEnabledError{Name: name}
+	}
+
+	var sshRoute route_helpers.DiegoSSHRoute
+	if err := json.Unmarshal(*rawMessage, &sshRoute); err != nil {
+		return route_helpers.DiegoSSHRoute{}, err
+	}
+
+	return sshRoute, nil
+}
+
+func publicKeyFromPEM(privateKeyPEM string) (ssh.PublicKey, error) {
+	signer, err := ssh.ParsePrivateKey([]byte(privateKeyPEM))
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.PublicKey(), nil
 }
 
 func (appRunner *appRunner) desiredLRPExists(name string) (exists bool, err error) {
 	desiredLRPs, err := appRunner.receptorClient.DesiredLRPs()
 	if err != nil {
-		return false, err
+		return false, apperrors.WrapReceptorError(err)
 	}
 
 	for _, desiredLRP := range desiredLRPs {
@@ -175,8 +433,10 @@ func (appRunner *appRunner) desireLrp(params CreateDockerAppParams) error {
 		return err
 	}
 
+	primaryPort := GetPrimaryPort(params.Monitor.Port, params.ExposedPorts)
+
 	envVars := buildEnvironmentVariables(params.EnvironmentVariables)
-	envVars = append(envVars, receptor.EnvironmentVariable{Name: "PORT", Value: fmt.Sprintf("%d", params.Monitor.Port)})
+	envVars = append(envVars, receptor.EnvironmentVariable{Name: "PORT", Value: fmt.Sprintf("%d", primaryPort)})
 
 	var appRoutes route_helpers.AppRoutes
 	if params.NoRoutes {
@@ -193,7 +453,61 @@ func (appRunner *appRunner) desireLrp(params CreateDockerAppParams) error {
 			})
 		}
 	} else {
-		appRoutes = appRunner.buildDefaultRoutingInfo(params.Name, params.ExposedPorts, params.Monitor.Port)
+		appRoutes = appRunner.buildDefaultRoutingInfo(params.Name, params.ExposedPorts, primaryPort)
+	}
+
+	vcapApplication, err := buildVcapApplication(params, appRoutes)
+	if err != nil {
+		return err
+	}
+	envVars = append(envVars, receptor.EnvironmentVariable{Name: "VCAP_APPLICATION", Value: string(vcapApplication)})
+
+	routingInfo := appRoutes.RoutingInfo()
+	var sshAuthorizedKey string
+	var sshHostKey []byte
+	if params.EnableSSH {
+		privateKey, publicKey, err := appRunner.keyGenerator.GenerateRSAKeyPair()
+		if err != nil {
+			return err
+		}
+
+		sshHostKey, _, err = appRunner.keyGenerator.GenerateRSAKeyPair()
+		if err != nil {
+			return err
+		}
+
+		sshAuthorizedKey = string(ssh.MarshalAuthorizedKey(publicKey))
+
+		sshRouteJson, err := json.Marshal(route_helpers.DiegoSSHRoute{
+			Port:       sshProxyPort,
+			PrivateKey: string(privateKey),
+		})
+		if err != nil {
+			return err
+		}
+		rawMessage := json.RawMessage(sshRouteJson)
+		routingInfo[route_helpers.DiegoSSHRouteName] = &rawMessage
+	}
+
+	exposedPorts := params.ExposedPorts
+	if params.EnableSSH {
+		exposedPorts = append(exposedPorts, sshProxyPort)
+	}
+
+	setup := models.Action(&models.DownloadAction{
+		From: healthcheckDownloadUrl,
+		To:   "/tmp",
+	})
+	if params.EnableSSH {
+		setup = &models.ParallelAction{
+			Actions: []models.Action{
+				setup,
+				&models.DownloadAction{
+					From: sshdDownloadUrl,
+					To:   "/tmp",
+				},
+			},
+		}
 	}
 
 	req := receptor.DesiredLRPCreateRequest{
@@ -201,20 +515,17 @@ func (appRunner *appRunner) desireLrp(params CreateDockerAppParams) error {
 		Domain:               lrpDomain,
 		RootFS:               dockerImageUrl,
 		Instances:            params.Instances,
-		Routes:               appRoutes.RoutingInfo(),
+		Routes:               routingInfo,
 		CPUWeight:            params.CPUWeight,
 		MemoryMB:             params.MemoryMB,
 		DiskMB:               params.DiskMB,
 		Privileged:           true,
-		Ports:                params.ExposedPorts,
+		Ports:                exposedPorts,
 		LogGuid:              params.Name,
 		LogSource:            "APP",
 		MetricsGuid:          params.Name,
 		EnvironmentVariables: envVars,
-		Setup: &models.DownloadAction{
-			From: healthcheckDownloadUrl,
-			To:   "/tmp",
-		},
+		Setup:                setup,
 		Action: &models.RunAction{
 			Path:       params.StartCommand,
 			Args:       params.AppArgs,
@@ -223,6 +534,15 @@ func (appRunner *appRunner) desireLrp(params CreateDockerAppParams) error {
 		},
 	}
 
+	if params.EnableSSH {
+		req.Action = &models.ParallelAction{
+			Actions: []models.Action{
+				req.Action,
+				sshdRunAction(sshHostKey, sshAuthorizedKey),
+			},
+		}
+	}
+
 	var healthCheckArgs []string
 	if params.Monitor.Timeout != 0 {
 		healthCheckArgs = append(healthCheckArgs, "-timeout", fmt.Sprint(params.Monitor.Timeout))
@@ -240,9 +560,30 @@ func (appRunner *appRunner) desireLrp(params CreateDockerAppParams) error {
 			Args:      append(healthCheckArgs, "-port", fmt.Sprint(params.Monitor.Port), "-uri", params.Monitor.URI),
 			LogSource: "HEALTH",
 		}
+	case CommandMonitor:
+		commandPath, commandArgs := commandMonitorAction(params.Monitor.Command)
+		commandAction := &models.RunAction{
+			Path:      commandPath,
+			Args:      commandArgs,
+			User:      params.Monitor.User,
+			Dir:       params.Monitor.Dir,
+			LogSource: "HEALTH",
+		}
+		for name, value := range params.Monitor.Env {
+			commandAction.Env = append(commandAction.Env, &models.EnvironmentVariable{Name: name, Value: value})
+		}
+
+		if params.Monitor.Timeout != 0 {
+			req.Monitor = &models.TimeoutAction{
+				Action:  commandAction,
+				Timeout: params.Monitor.Timeout,
+			}
+		} else {
+			req.Monitor = commandAction
+		}
 	}
 
-	return appRunner.receptorClient.CreateDesiredLRP(req)
+	return apperrors.WrapReceptorError(appRunner.receptorClient.CreateDesiredLRP(req))
 }
 
 func (appRunner *appRunner) updateLrpInstances(name string, instances int) error {
@@ -253,7 +594,7 @@ func (appRunner *appRunner) updateLrpInstances(name string, instances int) error
 		},
 	)
 
-	return err
+	return apperrors.WrapReceptorError(err)
 }
 
 func (appRunner *appRunner) updateLrpRoutes(name string, routes RouteOverrides) error {
@@ -277,7 +618,7 @@ func (appRunner *appRunner) updateLrpRoutes(name string, routes RouteOverrides)
 		},
 	)
 
-	return err
+	return apperrors.WrapReceptorError(err)
 }
 
 func (appRunner *appRunner) buildDefaultRoutingInfo(appName string, exposedPorts []uint16, monitorPort uint16) route_helpers.AppRoutes {
@@ -299,6 +640,20 @@ func (appRunner *appRunner) buildDefaultRoutingInfo(appName string, exposedPorts
 	return appRoutes
 }
 
+// commandMonitorAction turns a health-check Monitor.Command into a Path/Args
+// pair. A single-element command is treated as a shell string and run
+// through "sh -c", preserving pipes and redirection; a multi-element
+// command is treated as an argv and run directly, with no shell involved.
+func commandMonitorAction(command []string) (string, []string) {
+	if len(command) == 1 {
+		return "sh", []string{"-c", command[0]}
+	}
+	if len(command) > 1 {
+		return command[0], command[1:]
+	}
+	return "true", nil
+}
+
 func buildEnvironmentVariables(environmentVariables map[string]string) []receptor.EnvironmentVariable {
 	appEnvVars := make([]receptor.EnvironmentVariable, 0, len(environmentVariables)+1)
 	for name, value := range environmentVariables {
@@ -306,3 +661,47 @@ func buildEnvironmentVariables(environmentVariables map[string]string) []recepto
 	}
 	return appEnvVars
 }
+
+// GetPrimaryPort returns the port that PORT and the app's default route
+// should advertise: the configured monitor port, or the first exposed port
+// when no monitor port was set.
+func GetPrimaryPort(monitorPort uint16, exposedPorts []uint16) uint16 {
+	if monitorPort != 0 {
+		return monitorPort
+	}
+	if len(exposedPorts) > 0 {
+		return exposedPorts[0]
+	}
+	return 0
+}
+
+type vcapApplicationLimits struct {
+	Disk int `json:"disk"`
+	Mem  int `json:"mem"`
+}
+
+type vcapApplication struct {
+	ApplicationName string                `json:"application_name"`
+	Name            string                `json:"name"`
+	ApplicationUris []string              `json:"application_uris"`
+	Uris            []string              `json:"uris"`
+	Limits          vcapApplicationLimits `json:"limits"`
+}
+
+func buildVcapApplication(params CreateDockerAppParams, appRoutes route_helpers.AppRoutes) ([]byte, error) {
+	uris := []string{}
+	for _, appRoute := range appRoutes {
+		uris = append(uris, appRoute.Hostnames...)
+	}
+
+	return json.Marshal(vcapApplication{
+		ApplicationName: params.Name,
+		Name:            params.Name,
+		ApplicationUris: uris,
+		Uris:            uris,
+		Limits: vcapApplicationLimits{
+			Disk: params.DiskMB,
+			Mem:  params.MemoryMB,
+		},
+	})
+}
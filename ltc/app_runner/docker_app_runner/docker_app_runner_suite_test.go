@@ -0,0 +1,13 @@
+package docker_app_runner
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestDockerAppRunner(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Docker App Runner Suite")
+}
@@ -0,0 +1,59 @@
+package docker_app_runner
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/lattice/ltc/route_helpers"
+)
+
+var _ = Describe("GetPrimaryPort", func() {
+	It("prefers the monitor port when one is set", func() {
+		Expect(GetPrimaryPort(8080, []uint16{9090})).To(Equal(uint16(8080)))
+	})
+
+	It("falls back to the first exposed port when there is no monitor port", func() {
+		Expect(GetPrimaryPort(0, []uint16{9090, 9091})).To(Equal(uint16(9090)))
+	})
+
+	It("returns 0 when there is no monitor port or exposed port", func() {
+		Expect(GetPrimaryPort(0, nil)).To(Equal(uint16(0)))
+	})
+})
+
+var _ = Describe("buildVcapApplication", func() {
+	It("flattens the app's route hostnames into application_uris and uris", func() {
+		appRoutes := route_helpers.AppRoutes{
+			{Hostnames: []string{"my-app.lattice.cf-app.com"}, Port: 8080},
+			{Hostnames: []string{"my-app-9090.lattice.cf-app.com"}, Port: 9090},
+		}
+
+		vcapApplicationJson, err := buildVcapApplication(CreateDockerAppParams{Name: "my-app", DiskMB: 512, MemoryMB: 128}, appRoutes)
+		Expect(err).NotTo(HaveOccurred())
+
+		var vcap vcapApplication
+		Expect(json.Unmarshal(vcapApplicationJson, &vcap)).To(Succeed())
+
+		Expect(vcap.ApplicationName).To(Equal("my-app"))
+		Expect(vcap.Name).To(Equal("my-app"))
+		Expect(vcap.ApplicationUris).To(ConsistOf("my-app.lattice.cf-app.com", "my-app-9090.lattice.cf-app.com"))
+		Expect(vcap.Uris).To(ConsistOf("my-app.lattice.cf-app.com", "my-app-9090.lattice.cf-app.com"))
+		Expect(vcap.Limits.Disk).To(Equal(512))
+		Expect(vcap.Limits.Mem).To(Equal(128))
+	})
+
+	It("emits an empty array, not null, when there are no routes", func() {
+		vcapApplicationJson, err := buildVcapApplication(CreateDockerAppParams{Name: "my-app"}, route_helpers.AppRoutes{})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(vcapApplicationJson).To(MatchJSON(`{
+			"application_name": "my-app",
+			"name": "my-app",
+			"application_uris": [],
+			"uris": [],
+			"limits": {"disk": 0, "mem": 0}
+		}`))
+	})
+})
@@ -0,0 +1,42 @@
+package docker_app_runner
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const defaultSSHKeyBits = 2048
+
+//go:generate counterfeiter -o fake_key_generator/fake_key_generator.go . KeyGenerator
+type KeyGenerator interface {
+	GenerateRSAKeyPair() (privateKeyPEM []byte, publicKey ssh.PublicKey, err error)
+}
+
+type rsaKeyGenerator struct{}
+
+func NewRSAKeyGenerator() KeyGenerator {
+	return &rsaKeyGenerator{}
+}
+
+func (rsaKeyGenerator) GenerateRSAKeyPair() ([]byte, ssh.PublicKey, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, defaultSSHKeyBits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	publicKey, err := ssh.NewPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
+	})
+
+	return privateKeyPEM, publicKey, nil
+}
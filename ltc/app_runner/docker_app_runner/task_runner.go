@@ -0,0 +1,103 @@
+package docker_app_runner
+
+import (
+	"encoding/json"
+
+	"github.com/cloudfoundry-incubator/lattice/ltc/app_runner/apperrors"
+	"github.com/cloudfoundry-incubator/lattice/ltc/app_runner/docker_repository_name_formatter"
+	"github.com/cloudfoundry-incubator/lattice/ltc/logs/reserved_app_ids"
+	"github.com/cloudfoundry-incubator/receptor"
+	"github.com/cloudfoundry-incubator/runtime-schema/models"
+)
+
+type CreateDockerTaskParams struct {
+	Name                  string
+	StartCommand          string
+	DockerImagePath       string
+	AppArgs               []string
+	EnvironmentVariables  map[string]string
+	Privileged            bool
+	CPUWeight             uint
+	MemoryMB              int
+	DiskMB                int
+	ExposedPorts          []uint16
+	WorkingDir            string
+	ResultFile            string
+	CompletionCallbackURL string
+	Annotation            string
+}
+
+//go:generate counterfeiter -o fake_task_runner/fake_task_runner.go . TaskRunner
+type TaskRunner interface {
+	CreateDockerTask(params CreateDockerTaskParams) error
+	SubmitTask(taskJson []byte) (string, error)
+	CancelTask(taskGuid string) error
+	DeleteTask(taskGuid string) error
+}
+
+type taskRunner struct {
+	receptorClient receptor.Client
+}
+
+func NewTaskRunner(receptorClient receptor.Client) TaskRunner {
+	return &taskRunner{receptorClient}
+}
+
+func (taskRunner *taskRunner) CreateDockerTask(params CreateDockerTaskParams) error {
+	if params.Name == reserved_app_ids.LatticeDebugLogStreamAppId {
+		return apperrors.ReservedAppNameError{Name: params.Name}
+	}
+
+	dockerImageUrl, err := docker_repository_name_formatter.FormatForReceptor(params.DockerImagePath)
+	if err != nil {
+		return err
+	}
+
+	req := receptor.TaskCreateRequest{
+		TaskGuid:              params.Name,
+		Domain:                lrpDomain,
+		RootFS:                dockerImageUrl,
+		ResultFile:            params.ResultFile,
+		CompletionCallbackURL: params.CompletionCallbackURL,
+		Annotation:            params.Annotation,
+		CPUWeight:             params.CPUWeight,
+		MemoryMB:              params.MemoryMB,
+		DiskMB:                params.DiskMB,
+		Privileged:            true,
+		Ports:                 params.ExposedPorts,
+		LogGuid:               params.Name,
+		LogSource:             "APP",
+		EnvironmentVariables:  buildEnvironmentVariables(params.EnvironmentVariables),
+		Action: &models.RunAction{
+			Path:       params.StartCommand,
+			Args:       params.AppArgs,
+			Privileged: params.Privileged,
+			Dir:        params.WorkingDir,
+		},
+	}
+
+	return apperrors.WrapReceptorError(taskRunner.receptorClient.CreateTask(req))
+}
+
+func (taskRunner *taskRunner) SubmitTask(taskJson []byte) (string, error) {
+	taskCreateRequest := receptor.TaskCreateRequest{}
+
+	if err := json.Unmarshal(taskJson, &taskCreateRequest); err != nil {
+		return "", err
+	}
+
+	if taskCreateRequest.TaskGuid == reserved_app_ids.LatticeDebugLogStreamAppId {
+		return taskCreateRequest.TaskGuid, apperrors.ReservedAppNameError{Name: taskCreateRequest.TaskGuid}
+	}
+
+	err := taskRunner.receptorClient.CreateTask(taskCreateRequest)
+	return taskCreateRequest.TaskGuid, apperrors.WrapReceptorError(err)
+}
+
+func (taskRunner *taskRunner) CancelTask(taskGuid string) error {
+	return apperrors.WrapReceptorError(taskRunner.receptorClient.CancelTask(taskGuid))
+}
+
+func (taskRunner *taskRunner) DeleteTask(taskGuid string) error {
+	return apperrors.WrapReceptorError(taskRunner.receptorClient.DeleteTask(taskGuid))
+}
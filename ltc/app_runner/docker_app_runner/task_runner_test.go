@@ -0,0 +1,34 @@
+package docker_app_runner
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/cloudfoundry-incubator/lattice/ltc/app_runner/apperrors"
+	"github.com/cloudfoundry-incubator/lattice/ltc/logs/reserved_app_ids"
+)
+
+var _ = Describe("taskRunner", func() {
+	var runner *taskRunner
+
+	BeforeEach(func() {
+		runner = &taskRunner{}
+	})
+
+	Describe("CreateDockerTask", func() {
+		It("rejects the reserved lattice-debug task name before touching the receptor", func() {
+			err := runner.CreateDockerTask(CreateDockerTaskParams{Name: reserved_app_ids.LatticeDebugLogStreamAppId})
+			Expect(err).To(Equal(apperrors.ReservedAppNameError{Name: reserved_app_ids.LatticeDebugLogStreamAppId}))
+		})
+	})
+
+	Describe("SubmitTask", func() {
+		It("rejects the reserved lattice-debug task name before touching the receptor", func() {
+			taskJson := []byte(`{"task_guid": "` + reserved_app_ids.LatticeDebugLogStreamAppId + `"}`)
+
+			taskGuid, err := runner.SubmitTask(taskJson)
+			Expect(taskGuid).To(Equal(reserved_app_ids.LatticeDebugLogStreamAppId))
+			Expect(err).To(Equal(apperrors.ReservedAppNameError{Name: reserved_app_ids.LatticeDebugLogStreamAppId}))
+		})
+	})
+})